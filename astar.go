@@ -0,0 +1,177 @@
+package main
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type AStarSearch struct {
+	Frontier priorityQueue
+	Game     *Maze
+}
+
+// Add a node to the frontier, prioritized by f = g (Cost) + h (Manhattan
+// distance to the goal).
+func (a *AStarSearch) Add(i *Node) {
+	heap.Push(&a.Frontier, &pqItem{node: i, priority: i.Cost + heuristic(i.State, a.Game.Goal)})
+}
+
+// ContainsState reports whether a state is already on the frontier. A
+// shorter path to an already-queued state replaces it instead of being
+// skipped.
+func (a *AStarSearch) ContainsState(i *Node) bool {
+	idx := a.Frontier.indexOfState(i.State)
+	if idx == -1 {
+		return false
+	}
+	if i.Cost < a.Frontier[idx].node.Cost {
+		a.Frontier[idx].node = i
+		a.Frontier[idx].priority = i.Cost + heuristic(i.State, a.Game.Goal)
+		heap.Fix(&a.Frontier, idx)
+	}
+	return true
+}
+
+// Check if the frontier is empty
+func (a *AStarSearch) Empty() bool {
+	return len(a.Frontier) == 0
+}
+
+// Remove and return the node with the lowest f = g + h.
+func (a *AStarSearch) Remove() (*Node, error) {
+	if len(a.Frontier) == 0 {
+		return nil, errors.New("empty frontier")
+	}
+	if a.Game.Debug {
+		fmt.Println("Frontier before remove:")
+		for _, x := range a.Frontier {
+			fmt.Println("Node:", x.node.State)
+		}
+	}
+	item := heap.Pop(&a.Frontier).(*pqItem)
+	return item.node, nil
+}
+
+// this is what solves the maze
+func (a *AStarSearch) Solve() {
+	fmt.Println("Starting to solve maze with A* Search")
+	a.Game.NumExplored = 0
+	start := Node{
+		State:  a.Game.Start,
+		Parent: nil,
+		Action: "",
+	}
+	a.Add(&start)
+
+	// where am i
+	a.Game.CurrentNode = start
+
+	for {
+		if a.Empty() {
+			return
+		}
+		currentNode, err := a.Remove()
+		if err != nil {
+			fmt.Println("Error removing node from frontier:", err)
+			return
+		}
+		if a.Game.Debug {
+			fmt.Println("Removed:", currentNode.State)
+			fmt.Println("---------")
+			fmt.Println("")
+		}
+		a.Game.CurrentNode = *currentNode
+		a.Game.NumExplored++
+
+		// have we reached the goal?
+		if a.Game.Goal == currentNode.State {
+			var actions []string
+			var cells []Point
+
+			for {
+				if currentNode.Parent != nil {
+					actions = append(actions, currentNode.Action)
+					cells = append(cells, currentNode.State)
+					currentNode = currentNode.Parent
+				} else {
+					break
+				}
+			}
+			// reverse the actions and cells
+			slices.Reverse(actions)
+			slices.Reverse(cells)
+
+			a.Game.Solution = Solution{
+				Action: actions,
+				Cells:  cells,
+			}
+
+			// record the goal cell as explored
+			a.Game.Explored = append(a.Game.Explored, a.Game.CurrentNode.State)
+			return
+		}
+		a.Game.Explored = append(a.Game.Explored, currentNode.State)
+
+		// add neighbors to the frontier
+		for _, x := range a.Neighbors(currentNode) {
+			x.Cost = currentNode.Cost + 1
+			if !a.ContainsState(x) {
+				if !inExplored(x.State, a.Game.Explored) {
+					a.Add(&Node{
+						State:  x.State,
+						Parent: currentNode,
+						Action: x.Action,
+						Cost:   currentNode.Cost + 1,
+					})
+				}
+			}
+		}
+	}
+}
+
+func (a *AStarSearch) Neighbors(node *Node) []*Node {
+	row := node.State.Row
+	col := node.State.Col
+	candidates := []*Node{
+		{
+			State:  Point{Row: row - 1, Col: col},
+			Parent: node,
+			Action: "up",
+		},
+		{
+			State:  Point{Row: row, Col: col - 1},
+			Parent: node,
+			Action: "left",
+		},
+		{
+			State:  Point{Row: row, Col: col + 1},
+			Parent: node,
+			Action: "right",
+		},
+		{
+			State:  Point{Row: row + 1, Col: col},
+			Parent: node,
+			Action: "down",
+		},
+	}
+	var neighbors []*Node
+	for _, x := range candidates {
+		if 0 <= x.State.Row && x.State.Row < a.Game.Height {
+			if 0 <= x.State.Col && x.State.Col < a.Game.Width {
+				if a.Game.Raw.IsPath(x.State.Col, x.State.Row) {
+					neighbors = append(neighbors, x)
+				}
+			}
+		}
+	}
+	if dest, ok := a.Game.Portals[node.State]; ok {
+		neighbors = append(neighbors, &Node{
+			State:  dest,
+			Parent: node,
+			Action: "warp",
+		})
+	}
+	return neighbors
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildPortalMaze returns a small donut-style maze where the only way from
+// A to B is through a matched pair of "CD" portal labels.
+func buildPortalMaze() string {
+	rows := []string{
+		strings.Repeat("#", 11),
+		"#A" + strings.Repeat("#", 7) + "B#",
+		"#" + " " + strings.Repeat("#", 7) + " " + "#",
+		"#" + " " + "CD" + "###" + "CD" + " " + "#",
+		strings.Repeat("#", 11),
+	}
+	return strings.Join(rows, "\n") + "\n"
+}
+
+func TestAStarSolvesMazeThroughPortal(t *testing.T) {
+	mazeFile := filepath.Join(t.TempDir(), "portal.txt")
+	if err := os.WriteFile(mazeFile, []byte(buildPortalMaze()), 0o644); err != nil {
+		t.Fatalf("failed to write maze file: %v", err)
+	}
+
+	var m Maze
+	if err := m.Load(mazeFile, "text"); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if len(m.Portals) != 2 {
+		t.Fatalf("expected a single bidirectional portal pair (2 map entries), got %d", len(m.Portals))
+	}
+
+	var s AStarSearch
+	s.Game = &m
+	s.Solve()
+
+	if len(m.Solution.Cells) == 0 {
+		t.Fatal("expected A* to find a solution using the portal, found none")
+	}
+
+	sawWarp := false
+	for _, action := range m.Solution.Action {
+		if action == "warp" {
+			sawWarp = true
+		}
+	}
+	if !sawWarp {
+		t.Errorf("expected solution to use a portal warp, actions were %v", m.Solution.Action)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type BreadthFirstSearch struct {
+	Frontier []*Node
+	Game     *Maze
+}
+
+// Return the current frontier
+func (bfs *BreadthFirstSearch) GetFrontier() []*Node {
+	return bfs.Frontier
+}
+
+// Add a node to the end of the slice
+func (bfs *BreadthFirstSearch) Add(i *Node) {
+	bfs.Frontier = append(bfs.Frontier, i)
+}
+
+// ContainsState reports whether a state is already on the frontier. A
+// shorter path to an already-queued state replaces it instead of being
+// skipped.
+func (bfs *BreadthFirstSearch) ContainsState(i *Node) bool {
+	for _, x := range bfs.Frontier {
+		if x.State == i.State {
+			if i.Cost < x.Cost {
+				*x = *i
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Check if the frontier is empty
+func (bfs *BreadthFirstSearch) Empty() bool {
+	return len(bfs.Frontier) == 0
+}
+
+// Remove and return the first node (FIFO)
+func (bfs *BreadthFirstSearch) Remove() (*Node, error) {
+	if len(bfs.Frontier) > 0 {
+		if bfs.Game.Debug {
+			fmt.Println("Frontier before remove:")
+			for _, x := range bfs.Frontier {
+				fmt.Println("Node:", x.State)
+			}
+		}
+		node := bfs.Frontier[0]
+		bfs.Frontier = bfs.Frontier[1:]
+		return node, nil
+	}
+	return nil, errors.New("empty frontier")
+}
+
+// this is what solves the maze
+func (bfs *BreadthFirstSearch) Solve() {
+	fmt.Println("Starting to solve maze with Breadth First Search")
+	bfs.Game.NumExplored = 0
+	start := Node{
+		State:  bfs.Game.Start,
+		Parent: nil,
+		Action: "",
+	}
+	bfs.Add(&start)
+
+	// where am i
+	bfs.Game.CurrentNode = start
+
+	for {
+		if bfs.Empty() {
+			return
+		}
+		currentNode, err := bfs.Remove()
+		if err != nil {
+			fmt.Println("Error removing node from frontier:", err)
+			return
+		}
+		if bfs.Game.Debug {
+			fmt.Println("Removed:", currentNode.State)
+			fmt.Println("---------")
+			fmt.Println("")
+		}
+		bfs.Game.CurrentNode = *currentNode
+		bfs.Game.NumExplored++
+
+		// have we reached the goal?
+		if bfs.Game.Goal == currentNode.State {
+			var actions []string
+			var cells []Point
+
+			for {
+				if currentNode.Parent != nil {
+					actions = append(actions, currentNode.Action)
+					cells = append(cells, currentNode.State)
+					currentNode = currentNode.Parent
+				} else {
+					break
+				}
+			}
+			// reverse the actions and cells
+			slices.Reverse(actions)
+			slices.Reverse(cells)
+
+			bfs.Game.Solution = Solution{
+				Action: actions,
+				Cells:  cells,
+			}
+
+			// record the goal cell as explored
+			bfs.Game.Explored = append(bfs.Game.Explored, bfs.Game.CurrentNode.State)
+			return
+		}
+		bfs.Game.Explored = append(bfs.Game.Explored, currentNode.State)
+
+		// add neighbors to the frontier
+		for _, x := range bfs.Neighbors(currentNode) {
+			x.Cost = currentNode.Cost + 1
+			if !bfs.ContainsState(x) {
+				if !inExplored(x.State, bfs.Game.Explored) {
+					bfs.Add(&Node{
+						State:  x.State,
+						Parent: currentNode,
+						Action: x.Action,
+						Cost:   currentNode.Cost + 1,
+					})
+				}
+			}
+		}
+	}
+}
+
+func (bfs *BreadthFirstSearch) Neighbors(node *Node) []*Node {
+	row := node.State.Row
+	col := node.State.Col
+	candidates := []*Node{
+		{
+			State:  Point{Row: row - 1, Col: col},
+			Parent: node,
+			Action: "up",
+		},
+		{
+			State:  Point{Row: row, Col: col - 1},
+			Parent: node,
+			Action: "left",
+		},
+		{
+			State:  Point{Row: row, Col: col + 1},
+			Parent: node,
+			Action: "right",
+		},
+		{
+			State:  Point{Row: row + 1, Col: col},
+			Parent: node,
+			Action: "down",
+		},
+	}
+	var neighbors []*Node
+	for _, x := range candidates {
+		if 0 <= x.State.Row && x.State.Row < bfs.Game.Height {
+			if 0 <= x.State.Col && x.State.Col < bfs.Game.Width {
+				if bfs.Game.Raw.IsPath(x.State.Col, x.State.Row) {
+					neighbors = append(neighbors, x)
+				}
+			}
+		}
+	}
+	if dest, ok := bfs.Game.Portals[node.State]; ok {
+		neighbors = append(neighbors, &Node{
+			State:  dest,
+			Parent: node,
+			Action: "warp",
+		})
+	}
+	return neighbors
+}
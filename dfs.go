@@ -22,10 +22,15 @@ func (dfs *DepthFirstSearch) Add(i *Node) {
 	dfs.Frontier = append(dfs.Frontier, i)
 }
 
-// Remove and return the last node (LIFO)
+// ContainsState reports whether a state is already on the frontier. A
+// shorter path to an already-queued state replaces it instead of being
+// skipped.
 func (dfs *DepthFirstSearch) ContainsState(i *Node) bool {
 	for _, x := range dfs.Frontier {
 		if x.State == i.State {
+			if i.Cost < x.Cost {
+				*x = *i
+			}
 			return true
 		}
 	}
@@ -116,12 +121,14 @@ func (dfs *DepthFirstSearch) Solve() {
 
 		// add neighbors to the frontier
 		for _, x := range dfs.Neighbors(currentNode) {
+			x.Cost = currentNode.Cost + 1
 			if !dfs.ContainsState(x) {
 				if !inExplored(x.State, dfs.Game.Explored) {
 					dfs.Add(&Node{
 						State:  x.State,
 						Parent: currentNode,
 						Action: x.Action,
+						Cost:   currentNode.Cost + 1,
 					})
 				}
 			}
@@ -158,12 +165,19 @@ func (dfs *DepthFirstSearch) Neighbors(node *Node) []*Node {
 	for _, x := range candidates {
 		if 0 <= x.State.Row && x.State.Row < dfs.Game.Height {
 			if 0 <= x.State.Col && x.State.Col < dfs.Game.Width {
-				if !dfs.Game.Walls[x.State.Row][x.State.Col].wall {
+				if dfs.Game.Raw.IsPath(x.State.Col, x.State.Row) {
 					neighbors = append(neighbors, x)
 				}
 			}
 		}
 	}
+	if dest, ok := dfs.Game.Portals[node.State]; ok {
+		neighbors = append(neighbors, &Node{
+			State:  dest,
+			Parent: node,
+			Action: "warp",
+		})
+	}
 	for i := range neighbors {
 		j := rand.Intn(i + 1)
 		neighbors[i], neighbors[j] = neighbors[j], neighbors[i]
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// DijkstraSearch is a uniform-cost search: the frontier is ordered purely by
+// g (Cost) rather than f = g + h. With the unit-weight cells this module
+// currently uses it behaves exactly like BreadthFirstSearch, but building it
+// on the shared priorityQueue means weighted cells can be supported later
+// without changing the frontier type.
+type DijkstraSearch struct {
+	Frontier priorityQueue
+	Game     *Maze
+}
+
+// Add a node to the frontier, prioritized by path cost from the start.
+func (d *DijkstraSearch) Add(i *Node) {
+	heap.Push(&d.Frontier, &pqItem{node: i, priority: i.Cost})
+}
+
+// ContainsState reports whether a state is already on the frontier. A
+// shorter path to an already-queued state replaces it instead of being
+// skipped.
+func (d *DijkstraSearch) ContainsState(i *Node) bool {
+	idx := d.Frontier.indexOfState(i.State)
+	if idx == -1 {
+		return false
+	}
+	if i.Cost < d.Frontier[idx].node.Cost {
+		d.Frontier[idx].node = i
+		d.Frontier[idx].priority = i.Cost
+		heap.Fix(&d.Frontier, idx)
+	}
+	return true
+}
+
+// Check if the frontier is empty
+func (d *DijkstraSearch) Empty() bool {
+	return len(d.Frontier) == 0
+}
+
+// Remove and return the node with the lowest path cost.
+func (d *DijkstraSearch) Remove() (*Node, error) {
+	if len(d.Frontier) == 0 {
+		return nil, errors.New("empty frontier")
+	}
+	if d.Game.Debug {
+		fmt.Println("Frontier before remove:")
+		for _, x := range d.Frontier {
+			fmt.Println("Node:", x.node.State)
+		}
+	}
+	item := heap.Pop(&d.Frontier).(*pqItem)
+	return item.node, nil
+}
+
+// this is what solves the maze
+func (d *DijkstraSearch) Solve() {
+	fmt.Println("Starting to solve maze with Dijkstra's Algorithm")
+	d.Game.NumExplored = 0
+	start := Node{
+		State:  d.Game.Start,
+		Parent: nil,
+		Action: "",
+	}
+	d.Add(&start)
+
+	// where am i
+	d.Game.CurrentNode = start
+
+	for {
+		if d.Empty() {
+			return
+		}
+		currentNode, err := d.Remove()
+		if err != nil {
+			fmt.Println("Error removing node from frontier:", err)
+			return
+		}
+		if d.Game.Debug {
+			fmt.Println("Removed:", currentNode.State)
+			fmt.Println("---------")
+			fmt.Println("")
+		}
+		d.Game.CurrentNode = *currentNode
+		d.Game.NumExplored++
+
+		// have we reached the goal?
+		if d.Game.Goal == currentNode.State {
+			var actions []string
+			var cells []Point
+
+			for {
+				if currentNode.Parent != nil {
+					actions = append(actions, currentNode.Action)
+					cells = append(cells, currentNode.State)
+					currentNode = currentNode.Parent
+				} else {
+					break
+				}
+			}
+			// reverse the actions and cells
+			slices.Reverse(actions)
+			slices.Reverse(cells)
+
+			d.Game.Solution = Solution{
+				Action: actions,
+				Cells:  cells,
+			}
+
+			// record the goal cell as explored
+			d.Game.Explored = append(d.Game.Explored, d.Game.CurrentNode.State)
+			return
+		}
+		d.Game.Explored = append(d.Game.Explored, currentNode.State)
+
+		// add neighbors to the frontier
+		for _, x := range d.Neighbors(currentNode) {
+			x.Cost = currentNode.Cost + 1
+			if !d.ContainsState(x) {
+				if !inExplored(x.State, d.Game.Explored) {
+					d.Add(&Node{
+						State:  x.State,
+						Parent: currentNode,
+						Action: x.Action,
+						Cost:   currentNode.Cost + 1,
+					})
+				}
+			}
+		}
+	}
+}
+
+func (d *DijkstraSearch) Neighbors(node *Node) []*Node {
+	row := node.State.Row
+	col := node.State.Col
+	candidates := []*Node{
+		{
+			State:  Point{Row: row - 1, Col: col},
+			Parent: node,
+			Action: "up",
+		},
+		{
+			State:  Point{Row: row, Col: col - 1},
+			Parent: node,
+			Action: "left",
+		},
+		{
+			State:  Point{Row: row, Col: col + 1},
+			Parent: node,
+			Action: "right",
+		},
+		{
+			State:  Point{Row: row + 1, Col: col},
+			Parent: node,
+			Action: "down",
+		},
+	}
+	var neighbors []*Node
+	for _, x := range candidates {
+		if 0 <= x.State.Row && x.State.Row < d.Game.Height {
+			if 0 <= x.State.Col && x.State.Col < d.Game.Width {
+				if d.Game.Raw.IsPath(x.State.Col, x.State.Row) {
+					neighbors = append(neighbors, x)
+				}
+			}
+		}
+	}
+	if dest, ok := d.Game.Portals[node.State]; ok {
+		neighbors = append(neighbors, &Node{
+			State:  dest,
+			Parent: node,
+			Action: "warp",
+		})
+	}
+	return neighbors
+}
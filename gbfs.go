@@ -0,0 +1,175 @@
+package main
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type GreedyBestFirstSearch struct {
+	Frontier priorityQueue
+	Game     *Maze
+}
+
+// Add a node to the frontier, prioritized by Manhattan distance to the goal.
+func (g *GreedyBestFirstSearch) Add(i *Node) {
+	heap.Push(&g.Frontier, &pqItem{node: i, priority: heuristic(i.State, g.Game.Goal)})
+}
+
+// ContainsState reports whether a state is already on the frontier. A
+// shorter path to an already-queued state replaces it instead of being
+// skipped.
+func (g *GreedyBestFirstSearch) ContainsState(i *Node) bool {
+	idx := g.Frontier.indexOfState(i.State)
+	if idx == -1 {
+		return false
+	}
+	if i.Cost < g.Frontier[idx].node.Cost {
+		g.Frontier[idx].node = i
+		heap.Fix(&g.Frontier, idx)
+	}
+	return true
+}
+
+// Check if the frontier is empty
+func (g *GreedyBestFirstSearch) Empty() bool {
+	return len(g.Frontier) == 0
+}
+
+// Remove and return the node closest to the goal.
+func (g *GreedyBestFirstSearch) Remove() (*Node, error) {
+	if len(g.Frontier) == 0 {
+		return nil, errors.New("empty frontier")
+	}
+	if g.Game.Debug {
+		fmt.Println("Frontier before remove:")
+		for _, x := range g.Frontier {
+			fmt.Println("Node:", x.node.State)
+		}
+	}
+	item := heap.Pop(&g.Frontier).(*pqItem)
+	return item.node, nil
+}
+
+// this is what solves the maze
+func (g *GreedyBestFirstSearch) Solve() {
+	fmt.Println("Starting to solve maze with Greedy Best-First Search")
+	g.Game.NumExplored = 0
+	start := Node{
+		State:  g.Game.Start,
+		Parent: nil,
+		Action: "",
+	}
+	g.Add(&start)
+
+	// where am i
+	g.Game.CurrentNode = start
+
+	for {
+		if g.Empty() {
+			return
+		}
+		currentNode, err := g.Remove()
+		if err != nil {
+			fmt.Println("Error removing node from frontier:", err)
+			return
+		}
+		if g.Game.Debug {
+			fmt.Println("Removed:", currentNode.State)
+			fmt.Println("---------")
+			fmt.Println("")
+		}
+		g.Game.CurrentNode = *currentNode
+		g.Game.NumExplored++
+
+		// have we reached the goal?
+		if g.Game.Goal == currentNode.State {
+			var actions []string
+			var cells []Point
+
+			for {
+				if currentNode.Parent != nil {
+					actions = append(actions, currentNode.Action)
+					cells = append(cells, currentNode.State)
+					currentNode = currentNode.Parent
+				} else {
+					break
+				}
+			}
+			// reverse the actions and cells
+			slices.Reverse(actions)
+			slices.Reverse(cells)
+
+			g.Game.Solution = Solution{
+				Action: actions,
+				Cells:  cells,
+			}
+
+			// record the goal cell as explored
+			g.Game.Explored = append(g.Game.Explored, g.Game.CurrentNode.State)
+			return
+		}
+		g.Game.Explored = append(g.Game.Explored, currentNode.State)
+
+		// add neighbors to the frontier
+		for _, x := range g.Neighbors(currentNode) {
+			x.Cost = currentNode.Cost + 1
+			if !g.ContainsState(x) {
+				if !inExplored(x.State, g.Game.Explored) {
+					g.Add(&Node{
+						State:  x.State,
+						Parent: currentNode,
+						Action: x.Action,
+						Cost:   currentNode.Cost + 1,
+					})
+				}
+			}
+		}
+	}
+}
+
+func (g *GreedyBestFirstSearch) Neighbors(node *Node) []*Node {
+	row := node.State.Row
+	col := node.State.Col
+	candidates := []*Node{
+		{
+			State:  Point{Row: row - 1, Col: col},
+			Parent: node,
+			Action: "up",
+		},
+		{
+			State:  Point{Row: row, Col: col - 1},
+			Parent: node,
+			Action: "left",
+		},
+		{
+			State:  Point{Row: row, Col: col + 1},
+			Parent: node,
+			Action: "right",
+		},
+		{
+			State:  Point{Row: row + 1, Col: col},
+			Parent: node,
+			Action: "down",
+		},
+	}
+	var neighbors []*Node
+	for _, x := range candidates {
+		if 0 <= x.State.Row && x.State.Row < g.Game.Height {
+			if 0 <= x.State.Col && x.State.Col < g.Game.Width {
+				if g.Game.Raw.IsPath(x.State.Col, x.State.Row) {
+					neighbors = append(neighbors, x)
+				}
+			}
+		}
+	}
+	if dest, ok := g.Game.Portals[node.State]; ok {
+		neighbors = append(neighbors, &Node{
+			State:  dest,
+			Parent: node,
+			Action: "warp",
+		})
+	}
+	return neighbors
+}
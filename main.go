@@ -1,22 +1,23 @@
 package main
 
 import (
-	"bufio"
-	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
+
+	rawmaze "github.com/Epiq122/go-ai-search/maze"
 )
 
 const (
-	DFS      = iota // Depth-First Search
-	BFS             // Breadth-First Search
-	GBFS            // Greedy Best-First Search
-	AStar           // A* Search
-	DIJKSTRA        // Dijkstra's Algorithm
+	DFS       = iota // Depth-First Search
+	BFS              // Breadth-First Search
+	GBFS             // Greedy Best-First Search
+	AStar            // A* Search
+	DIJKSTRA         // Dijkstra's Algorithm
+	MULTIGOAL        // Multi-goal (TSP over points of interest) Search
 )
 
 // Directions for moving in the maze
@@ -25,17 +26,12 @@ type Point struct {
 	Col int
 }
 
-// Used to keep track of potential nodes that are walls and cannot be explored
-type Wall struct {
-	State Point
-	wall  bool
-}
-
 type Node struct {
 	index  int
 	State  Point
 	Parent *Node
 	Action string
+	Cost   int // path cost g(n) from the start to this node
 }
 
 type Solution struct {
@@ -45,11 +41,13 @@ type Solution struct {
 
 // Maze structure to hold the maze data
 type Maze struct {
-	Height      int      // how tall is the maze
-	Width       int      // how wide is the maze
-	Start       Point    // starting point
-	Goal        Point    // goal point
-	Walls       [][]Wall // slice of slices of wall type;
+	Height      int              // how tall is the maze
+	Width       int              // how wide is the maze
+	Start       Point            // starting point
+	Goal        Point            // goal point
+	Raw         *rawmaze.RawMaze // bit-packed walls; IsWall/IsPath replace indexing into a []Wall grid
+	Portals     map[Point]Point  // bidirectional teleport pairs, keyed by either endpoint
+	POIs        []Point          // points of interest (digit markers) a MultiGoalSolver must visit
 	CurrentNode Node
 	Solution    Solution
 	Explored    []Point
@@ -57,27 +55,75 @@ type Maze struct {
 	NumExplored int
 	Debug       bool
 	SearchType  int
+	ShowPlayer  bool  // true while Play is rendering a manual session
+	Player      Point // the player's current cell, only meaningful when ShowPlayer is set
 }
 
 func main() {
 	var m Maze
-	var maze, searchType string
+	var mazeFile, searchType, format, generate, save string
+	var play bool
 
-	flag.StringVar(&maze, "file", "maze.txt", "maze file")
-	flag.StringVar(&searchType, "search", "dfs", "search type")
+	flag.StringVar(&mazeFile, "file", "maze.txt", "maze file")
+	flag.StringVar(&searchType, "search", "dfs", "search type: dfs, bfs, gbfs, astar, dijkstra, multigoal")
+	flag.StringVar(&format, "format", "text", "maze file format: text, packed")
+	flag.BoolVar(&play, "play", false, "drop into an interactive play mode instead of running a solver")
+	flag.StringVar(&generate, "generate", "", "generate a random maze of size WxH (e.g. 21x21) instead of loading -file, then solve it")
+	flag.StringVar(&save, "save", "", "save the loaded or generated maze to this path in the packed format before solving")
 	flag.Parse()
 
-	err := m.Load(maze)
-	if err != nil {
+	if generate != "" {
+		width, height, err := parseDimensions(generate)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		g, err := (GeneratorReader{Width: width, Height: height, Seed: time.Now().UnixNano()}).Read()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		m = *g
+	} else if err := m.Load(mazeFile, format); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	if save != "" {
+		if err := m.Save(save, "packed"); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	if play {
+		if err := Play(&m); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	startTime := time.Now()
 	switch searchType {
 	case "dfs":
 		m.SearchType = DFS
 		solveDFS(&m)
+	case "bfs":
+		m.SearchType = BFS
+		solveBFS(&m)
+	case "gbfs":
+		m.SearchType = GBFS
+		solveGBFS(&m)
+	case "astar":
+		m.SearchType = AStar
+		solveAStar(&m)
+	case "dijkstra":
+		m.SearchType = DIJKSTRA
+		solveDijkstra(&m)
+	case "multigoal":
+		m.SearchType = MULTIGOAL
+		solveMultiGoal(&m)
 	default:
 		fmt.Println("Unknown search type:", searchType)
 		os.Exit(1)
@@ -94,14 +140,35 @@ func main() {
 	fmt.Println("Explored", len(m.Explored), "nodes")
 
 }
+
+// parseDimensions parses a "WxH" string, e.g. "21x21", as used by the
+// -generate flag.
+func parseDimensions(spec string) (width, height int, err error) {
+	parts := strings.SplitN(spec, "x", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid maze size %q, expected WxH (e.g. 21x21)", spec)
+	}
+	width, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maze width %q: %w", parts[0], err)
+	}
+	height, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maze height %q: %w", parts[1], err)
+	}
+	return width, height, nil
+}
+
 func (g *Maze) printMaze() {
-	for i, row := range g.Walls {
-		for j, col := range row {
-			if col.wall {
+	for i := 0; i < g.Height; i++ {
+		for j := 0; j < g.Width; j++ {
+			if g.ShowPlayer && g.Player.Row == i && g.Player.Col == j {
+				fmt.Print("@")
+			} else if g.Raw.IsWall(j, i) {
 				fmt.Print("â–‰")
-			} else if g.Start.Row == col.State.Row && g.Start.Col == col.State.Col {
+			} else if g.Start.Row == i && g.Start.Col == j {
 				fmt.Print("A")
-			} else if g.Goal.Row == col.State.Row && g.Goal.Col == col.State.Col {
+			} else if g.Goal.Row == i && g.Goal.Col == j {
 				fmt.Print("B")
 			} else if g.IsSolution(Point{Row: i, Col: j}) {
 				fmt.Print("*")
@@ -122,6 +189,16 @@ func (g *Maze) IsSolution(x Point) bool {
 	return false
 }
 
+// inExplored reports whether state has already been visited.
+func inExplored(state Point, explored []Point) bool {
+	for _, x := range explored {
+		if x == state {
+			return true
+		}
+	}
+	return false
+}
+
 func solveDFS(m *Maze) {
 	var s DepthFirstSearch
 	s.Game = m
@@ -129,80 +206,51 @@ func solveDFS(m *Maze) {
 	s.Solve()
 }
 
-// load the maze
-func (g *Maze) Load(fileName string) error {
-	f, err := os.Open(fileName)
-	if err != nil {
-		fmt.Printf("Error opening %s: %v\n", fileName, err)
+func solveBFS(m *Maze) {
+	var s BreadthFirstSearch
+	s.Game = m
+	fmt.Println("Goal is", s.Game.Goal)
+	s.Solve()
+}
 
-	}
-	defer f.Close()
+func solveGBFS(m *Maze) {
+	var s GreedyBestFirstSearch
+	s.Game = m
+	fmt.Println("Goal is", s.Game.Goal)
+	s.Solve()
+}
 
-	var fileContents []string
+func solveAStar(m *Maze) {
+	var s AStarSearch
+	s.Game = m
+	fmt.Println("Goal is", s.Game.Goal)
+	s.Solve()
+}
 
-	reader := bufio.NewReader(f)
-	for {
-		line, err := reader.ReadString('\n')
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return errors.New(fmt.Errorf("cannot open file %s: %v", fileName, err).Error())
-		}
-		fileContents = append(fileContents, line)
+func solveDijkstra(m *Maze) {
+	var s DijkstraSearch
+	s.Game = m
+	fmt.Println("Goal is", s.Game.Goal)
+	s.Solve()
+}
 
-	}
+func solveMultiGoal(m *Maze) {
+	var s MultiGoalSolver
+	s.Game = m
+	fmt.Println("Points of interest:", len(s.Game.POIs))
+	s.Solve()
+}
 
-	foundStart, foundEnd := false, false
-	for _, line := range fileContents {
-		if strings.Contains(line, "A") {
-			foundStart = true
-		}
-		if strings.Contains(line, "B") {
-			foundEnd = true
-		}
-	}
-	if !foundStart {
-		return errors.New("no start point 'A' found in the maze")
-	}
-	if !foundEnd {
-		return errors.New("no end point 'B' found in the maze")
-	}
-	g.Height = len(fileContents)
-	g.Width = len(fileContents[0])
-
-	var rows [][]Wall
-
-	for i, row := range fileContents {
-		var cols []Wall
-		for j, col := range row {
-			curLetter := fmt.Sprintf("%c", col)
-			var wall Wall
-			switch curLetter {
-			case "A":
-				g.Start = Point{Row: i, Col: j}
-				wall.State.Row = i
-				wall.State.Col = j
-				wall.wall = false
-			case "B":
-				g.Goal = Point{Row: i, Col: j}
-				wall.State.Row = i
-				wall.State.Col = j
-				wall.wall = false
-			case " ":
-				wall.State.Row = i
-				wall.State.Col = j
-				wall.wall = false
-			case "#":
-				wall.State.Row = i
-				wall.State.Col = j
-				wall.wall = true
-			default:
-				continue
-			}
-			cols = append(cols, wall)
-		}
-		rows = append(rows, cols)
+// Load reads a maze from fileName in the given format ("text", the
+// human-readable layout used throughout this package, or "packed", the
+// bit-packed binary format maze.RawMaze serializes to/from) and replaces
+// g's contents with it. It is a thin convenience wrapper around FileReader
+// for callers that don't need the Reader abstraction directly.
+func (g *Maze) Load(fileName, format string) error {
+	m, err := (FileReader{Path: fileName, Format: format}).Read()
+	if err != nil {
+		return err
 	}
-	g.Walls = rows
+	*g = *m
 	return nil
 }
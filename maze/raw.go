@@ -0,0 +1,63 @@
+// Package maze provides a compact bit-packed representation of a maze's
+// walls for grids too large to afford a struct per cell.
+package maze
+
+// RawMaze packs one bit per cell, eight cells per byte, instead of storing
+// a struct per cell. A 10000x10000 maze costs ~12.5MB this way instead of
+// the ~2.4GB a []Wall-per-cell grid would need.
+type RawMaze struct {
+	Width  int
+	Height int
+	data   [][]byte // data[y][x/8]; bit (7 - x%8) set means wall
+}
+
+// NewRawMaze allocates a width x height RawMaze with every cell a wall.
+func NewRawMaze(width, height int) *RawMaze {
+	rowBytes := (width + 7) / 8
+	data := make([][]byte, height)
+	for y := range data {
+		row := make([]byte, rowBytes)
+		for i := range row {
+			row[i] = 0xFF
+		}
+		data[y] = row
+	}
+	return &RawMaze{Width: width, Height: height, data: data}
+}
+
+// IsWall reports whether the cell at (x, y) is a wall.
+func (m *RawMaze) IsWall(x, y int) bool {
+	return m.data[y][x/8]&(1<<(7-uint(x%8))) != 0
+}
+
+// IsPath reports whether the cell at (x, y) can be walked through.
+func (m *RawMaze) IsPath(x, y int) bool {
+	return !m.IsWall(x, y)
+}
+
+// SetWall marks the cell at (x, y) as a wall or a path.
+func (m *RawMaze) SetWall(x, y int, wall bool) {
+	mask := byte(1 << (7 - uint(x%8)))
+	if wall {
+		m.data[y][x/8] |= mask
+	} else {
+		m.data[y][x/8] &^= mask
+	}
+}
+
+// Rows returns the raw packed bit rows, for serializing to the "packed"
+// on-disk format.
+func (m *RawMaze) Rows() [][]byte {
+	return m.data
+}
+
+// RowBytes returns the number of bytes used to pack one row of cells.
+func (m *RawMaze) RowBytes() int {
+	return (m.Width + 7) / 8
+}
+
+// SetRow replaces the packed bytes for row y. It is used when reading the
+// "packed" on-disk format, where rows arrive as whole byte slices.
+func (m *RawMaze) SetRow(y int, row []byte) {
+	m.data[y] = row
+}
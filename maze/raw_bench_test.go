@@ -0,0 +1,46 @@
+package maze
+
+import (
+	"testing"
+	"unsafe"
+)
+
+const benchSide = 10000
+
+// cellWall mirrors the old struct-per-cell representation this package
+// replaces: a coordinate plus a bool, once found in the main package's
+// []Wall grid.
+type cellWall struct {
+	x, y int
+	wall bool
+}
+
+// BenchmarkRawMazeMemory builds a 10000x10000 bit-packed maze and reports
+// its footprint, for comparison against BenchmarkStructGridMemory.
+func BenchmarkRawMazeMemory(b *testing.B) {
+	var m *RawMaze
+	for i := 0; i < b.N; i++ {
+		m = NewRawMaze(benchSide, benchSide)
+	}
+	rowBytes := m.RowBytes()
+	b.ReportMetric(float64(benchSide*rowBytes), "bytes")
+}
+
+// BenchmarkStructGridMemory builds the struct-per-cell grid RawMaze
+// replaces and reports its footprint: one cellWall (two ints plus a bool,
+// padded to 24 bytes on a 64-bit system) per cell instead of one bit.
+func BenchmarkStructGridMemory(b *testing.B) {
+	var grid [][]cellWall
+	for i := 0; i < b.N; i++ {
+		grid = make([][]cellWall, benchSide)
+		for y := range grid {
+			row := make([]cellWall, benchSide)
+			for x := range row {
+				row[x] = cellWall{x: x, y: y}
+			}
+			grid[y] = row
+		}
+	}
+	_ = grid
+	b.ReportMetric(float64(benchSide*benchSide)*float64(unsafe.Sizeof(cellWall{})), "bytes")
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MultiGoalSolver finds the shortest route that starts at the maze's Start
+// and visits every point of interest (POI) at least once. It works in two
+// phases: an all-pairs BFS over the maze grid to get the shortest distance
+// and concrete cell path between every pair of waypoints (Start plus each
+// POI), then a Held-Karp dynamic program over the small TSP that produces.
+type MultiGoalSolver struct {
+	Game *Maze
+}
+
+// waypointPath is the BFS result from one waypoint to another: the path
+// length in steps and the concrete actions/cells to get there.
+type waypointPath struct {
+	steps   int
+	actions []string
+	cells   []Point
+}
+
+// Solve computes the route and stores it as MultiGoalSolver.Game.Solution.
+func (m *MultiGoalSolver) Solve() {
+	fmt.Println("Starting to solve maze with Multi-Goal (TSP) Search")
+	waypoints := append([]Point{m.Game.Start}, m.Game.POIs...)
+	n := len(waypoints)
+
+	paths := make([]map[Point]*waypointPath, n)
+	for i, from := range waypoints {
+		paths[i] = m.bfsFrom(from)
+	}
+
+	dist := func(i, j int) (int, bool) {
+		p, ok := paths[i][waypoints[j]]
+		if !ok {
+			return 0, false
+		}
+		return p.steps, true
+	}
+
+	order, ok := heldKarp(n, dist)
+	if !ok {
+		fmt.Println("No route visiting every point of interest exists")
+		return
+	}
+
+	var actions []string
+	var cells []Point
+	for k := 1; k < len(order); k++ {
+		leg := paths[order[k-1]][waypoints[order[k]]]
+		actions = append(actions, leg.actions...)
+		cells = append(cells, leg.cells...)
+	}
+	m.Game.Solution = Solution{Action: actions, Cells: cells}
+}
+
+// bfsFrom explores the whole maze from start and returns, for every
+// reachable cell other than start, the shortest path from start to it.
+func (m *MultiGoalSolver) bfsFrom(start Point) map[Point]*waypointPath {
+	startNode := &Node{State: start}
+	visited := map[Point]*Node{start: startNode}
+	frontier := []*Node{startNode}
+
+	for len(frontier) > 0 {
+		current := frontier[0]
+		frontier = frontier[1:]
+
+		for _, x := range m.Neighbors(current) {
+			if _, seen := visited[x.State]; seen {
+				continue
+			}
+			visited[x.State] = x
+			frontier = append(frontier, x)
+		}
+	}
+
+	paths := make(map[Point]*waypointPath, len(visited))
+	for state, node := range visited {
+		if state == start {
+			continue
+		}
+		var actions []string
+		var cells []Point
+		for n := node; n.Parent != nil; n = n.Parent {
+			actions = append(actions, n.Action)
+			cells = append(cells, n.State)
+		}
+		slices.Reverse(actions)
+		slices.Reverse(cells)
+		paths[state] = &waypointPath{steps: len(cells), actions: actions, cells: cells}
+	}
+	return paths
+}
+
+func (m *MultiGoalSolver) Neighbors(node *Node) []*Node {
+	row := node.State.Row
+	col := node.State.Col
+	candidates := []*Node{
+		{
+			State:  Point{Row: row - 1, Col: col},
+			Parent: node,
+			Action: "up",
+		},
+		{
+			State:  Point{Row: row, Col: col - 1},
+			Parent: node,
+			Action: "left",
+		},
+		{
+			State:  Point{Row: row, Col: col + 1},
+			Parent: node,
+			Action: "right",
+		},
+		{
+			State:  Point{Row: row + 1, Col: col},
+			Parent: node,
+			Action: "down",
+		},
+	}
+	var neighbors []*Node
+	for _, x := range candidates {
+		if 0 <= x.State.Row && x.State.Row < m.Game.Height {
+			if 0 <= x.State.Col && x.State.Col < m.Game.Width {
+				if m.Game.Raw.IsPath(x.State.Col, x.State.Row) {
+					neighbors = append(neighbors, x)
+				}
+			}
+		}
+	}
+	if dest, ok := m.Game.Portals[node.State]; ok {
+		neighbors = append(neighbors, &Node{
+			State:  dest,
+			Parent: node,
+			Action: "warp",
+		})
+	}
+	return neighbors
+}
+
+// heldKarp solves the "visit every waypoint starting at index 0" TSP. dist
+// reports the edge cost from i to j, or ok=false if j is unreachable from
+// i. It returns the order of waypoint indices to visit (always starting
+// with 0), or ok=false if no route visits every waypoint.
+func heldKarp(n int, dist func(i, j int) (int, bool)) ([]int, bool) {
+	const inf = 1 << 30
+	subsets := 1 << n
+	fullMask := subsets - 1
+
+	dp := make([][]int, subsets)
+	parent := make([][]int, subsets)
+	for mask := range dp {
+		dp[mask] = make([]int, n)
+		parent[mask] = make([]int, n)
+		for j := range dp[mask] {
+			dp[mask][j] = inf
+			parent[mask][j] = -1
+		}
+	}
+	dp[1][0] = 0
+
+	for mask := 1; mask < subsets; mask++ {
+		if mask&1 == 0 {
+			continue // every state must include the start
+		}
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) == 0 || dp[mask][i] == inf {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if mask&(1<<j) != 0 {
+					continue
+				}
+				cost, ok := dist(i, j)
+				if !ok {
+					continue
+				}
+				next := mask | (1 << j)
+				if dp[mask][i]+cost < dp[next][j] {
+					dp[next][j] = dp[mask][i] + cost
+					parent[next][j] = i
+				}
+			}
+		}
+	}
+
+	best, bestJ := inf, -1
+	for j := 0; j < n; j++ {
+		if dp[fullMask][j] < best {
+			best = dp[fullMask][j]
+			bestJ = j
+		}
+	}
+	if bestJ == -1 {
+		return nil, false
+	}
+
+	var order []int
+	for mask, j := fullMask, bestJ; j != -1; {
+		order = append(order, j)
+		prevJ := parent[mask][j]
+		mask ^= 1 << j
+		j = prevJ
+	}
+	slices.Reverse(order)
+	return order, true
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildPOIMaze returns a single open corridor with two points of interest
+// ("1" and "2") between the start and the goal.
+func buildPOIMaze() string {
+	rows := []string{
+		strings.Repeat("#", 11),
+		"#A 1   2 B#",
+		strings.Repeat("#", 11),
+	}
+	return strings.Join(rows, "\n") + "\n"
+}
+
+func TestMultiGoalSolverVisitsAllPOIs(t *testing.T) {
+	mazeFile := filepath.Join(t.TempDir(), "pois.txt")
+	if err := os.WriteFile(mazeFile, []byte(buildPOIMaze()), 0o644); err != nil {
+		t.Fatalf("failed to write maze file: %v", err)
+	}
+
+	var m Maze
+	if err := m.Load(mazeFile, "text"); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(m.POIs) != 2 {
+		t.Fatalf("expected 2 points of interest, got %d", len(m.POIs))
+	}
+
+	var s MultiGoalSolver
+	s.Game = &m
+	s.Solve()
+
+	if len(m.Solution.Cells) != 6 {
+		t.Fatalf("expected the shortest route visiting both POIs to take 6 steps, got %d (%v)", len(m.Solution.Cells), m.Solution.Cells)
+	}
+
+	var visited1, visited2 bool
+	for _, c := range m.Solution.Cells {
+		if c == m.POIs[0] {
+			visited1 = true
+		}
+		if c == m.POIs[1] {
+			visited2 = true
+		}
+	}
+	if !visited1 || !visited2 {
+		t.Errorf("expected the route to pass through both points of interest, got %v", m.Solution.Cells)
+	}
+}
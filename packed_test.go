@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writePackedMaze hand-assembles a packed maze file matching loadPacked's
+// expected layout, for a maze with a single open row between two walls.
+func writePackedMaze(t *testing.T) string {
+	t.Helper()
+
+	const width, height = 4, 3
+	var buf bytes.Buffer
+	buf.WriteString(packedMagic)
+	header := [6]uint32{width, height, 1, 1, 1, 2} // start (1,1), goal (1,2)
+	if err := binary.Write(&buf, binary.BigEndian, header); err != nil {
+		t.Fatalf("failed to write header: %v", err)
+	}
+
+	walls := [height][width]bool{
+		{true, true, true, true},
+		{true, false, false, true},
+		{true, true, true, true},
+	}
+	for y := 0; y < height; y++ {
+		var b byte
+		for x := 0; x < width; x++ {
+			if walls[y][x] {
+				b |= 1 << (7 - uint(x))
+			}
+		}
+		buf.WriteByte(b)
+	}
+
+	path := filepath.Join(t.TempDir(), "packed.bin")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("failed to write packed maze file: %v", err)
+	}
+	return path
+}
+
+func TestLoadPackedFormat(t *testing.T) {
+	path := writePackedMaze(t)
+
+	var m Maze
+	if err := m.Load(path, "packed"); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if m.Width != 4 || m.Height != 3 {
+		t.Fatalf("expected a 4x3 maze, got %dx%d", m.Width, m.Height)
+	}
+	if m.Start != (Point{Row: 1, Col: 1}) {
+		t.Errorf("expected start (1,1), got %v", m.Start)
+	}
+	if m.Goal != (Point{Row: 1, Col: 2}) {
+		t.Errorf("expected goal (1,2), got %v", m.Goal)
+	}
+	if m.Raw.IsWall(1, 1) || m.Raw.IsWall(2, 1) {
+		t.Error("expected cells (1,1) and (2,1) to be open paths")
+	}
+	if !m.Raw.IsWall(0, 0) {
+		t.Error("expected cell (0,0) to be a wall")
+	}
+	if len(m.Portals) != 0 || len(m.POIs) != 0 {
+		t.Errorf("expected a hand-assembled file with no trailing portals/POIs section to load with none, got %d portals, %d POIs", len(m.Portals), len(m.POIs))
+	}
+}
+
+// TestSavePackedRoundTrip checks that a maze saved with Save("packed") loads
+// back to an equivalent maze.
+func TestSavePackedRoundTrip(t *testing.T) {
+	mazeFile := filepath.Join(t.TempDir(), "portal.txt")
+	if err := os.WriteFile(mazeFile, []byte(buildPortalMaze()), 0o644); err != nil {
+		t.Fatalf("failed to write maze file: %v", err)
+	}
+
+	var m Maze
+	if err := m.Load(mazeFile, "text"); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "portal.bin")
+	if err := m.Save(path, "packed"); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	var roundTripped Maze
+	if err := roundTripped.Load(path, "packed"); err != nil {
+		t.Fatalf("Load of saved packed maze returned error: %v", err)
+	}
+
+	if roundTripped.Width != m.Width || roundTripped.Height != m.Height {
+		t.Fatalf("expected %dx%d, got %dx%d", m.Width, m.Height, roundTripped.Width, roundTripped.Height)
+	}
+	if roundTripped.Start != m.Start {
+		t.Errorf("expected start %v, got %v", m.Start, roundTripped.Start)
+	}
+	if roundTripped.Goal != m.Goal {
+		t.Errorf("expected goal %v, got %v", m.Goal, roundTripped.Goal)
+	}
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if roundTripped.Raw.IsWall(x, y) != m.Raw.IsWall(x, y) {
+				t.Fatalf("wall mismatch at (%d,%d)", x, y)
+			}
+		}
+	}
+
+	if len(roundTripped.Portals) != len(m.Portals) {
+		t.Fatalf("expected %d portal entries to survive the round trip, got %d", len(m.Portals), len(roundTripped.Portals))
+	}
+	for from, to := range m.Portals {
+		if roundTripped.Portals[from] != to {
+			t.Errorf("expected portal %v -> %v to survive the round trip, got %v", from, to, roundTripped.Portals[from])
+		}
+	}
+
+	// buildPortalMaze is only solvable by using the portal; confirm the
+	// round-tripped maze still is, i.e. the portal data actually survived.
+	var s AStarSearch
+	s.Game = &roundTripped
+	s.Solve()
+	if len(roundTripped.Solution.Cells) == 0 {
+		t.Fatal("expected the round-tripped maze to still be solvable via its portal, found no solution")
+	}
+}
+
+// TestSavePackedRoundTripKeepsPOIs checks that a packed round trip doesn't
+// drop a maze's points of interest, so -search multigoal still works after
+// saving and reloading.
+func TestSavePackedRoundTripKeepsPOIs(t *testing.T) {
+	mazeFile := filepath.Join(t.TempDir(), "pois.txt")
+	if err := os.WriteFile(mazeFile, []byte(buildPOIMaze()), 0o644); err != nil {
+		t.Fatalf("failed to write maze file: %v", err)
+	}
+
+	var m Maze
+	if err := m.Load(mazeFile, "text"); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "pois.bin")
+	if err := m.Save(path, "packed"); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	var roundTripped Maze
+	if err := roundTripped.Load(path, "packed"); err != nil {
+		t.Fatalf("Load of saved packed maze returned error: %v", err)
+	}
+
+	if len(roundTripped.POIs) != len(m.POIs) {
+		t.Fatalf("expected %d points of interest to survive the round trip, got %d", len(m.POIs), len(roundTripped.POIs))
+	}
+	for i, poi := range m.POIs {
+		if roundTripped.POIs[i] != poi {
+			t.Errorf("expected POI %d to be %v, got %v", i, poi, roundTripped.POIs[i])
+		}
+	}
+}
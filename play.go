@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// Play drops the user into an interactive session: arrow keys move a
+// cursor from the maze's start toward its goal, walls block movement, and
+// a running step counter is shown until the goal is reached or the user
+// quits with 'q'. It exists alongside the automated solvers to build
+// intuition about why, say, DFS explores so many nodes on a given map.
+func Play(m *Maze) error {
+	fd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("cannot enter raw terminal mode: %w", err)
+	}
+	defer term.Restore(fd, oldState)
+
+	m.ShowPlayer = true
+	m.Player = m.Start
+	steps := 0
+
+	reader := bufio.NewReader(os.Stdin)
+	drawPlayState(m, steps, false)
+
+	for {
+		delta, quit, err := readMove(reader)
+		if err != nil {
+			return err
+		}
+		if quit {
+			return nil
+		}
+		if delta == (Point{}) {
+			continue
+		}
+
+		next := Point{Row: m.Player.Row + delta.Row, Col: m.Player.Col + delta.Col}
+		if !m.inBounds(next) || m.Raw.IsWall(next.Col, next.Row) {
+			continue
+		}
+		m.Player = next
+		steps++
+		if dest, ok := m.Portals[m.Player]; ok {
+			m.Player = dest
+		}
+
+		reached := m.Player == m.Goal
+		drawPlayState(m, steps, reached)
+		if reached {
+			return nil
+		}
+	}
+}
+
+func (g *Maze) inBounds(p Point) bool {
+	return 0 <= p.Row && p.Row < g.Height && 0 <= p.Col && p.Col < g.Width
+}
+
+// drawPlayState clears the screen and redraws the maze with the player's
+// current position plus a running step counter.
+func drawPlayState(m *Maze, steps int, reached bool) {
+	fmt.Print("\033[H\033[2J")
+	m.printMaze()
+	fmt.Printf("\r\nSteps: %d\r\n", steps)
+	if reached {
+		fmt.Print("Reached the goal!\r\n")
+	} else {
+		fmt.Print("Use the arrow keys to move, q to quit.\r\n")
+	}
+}
+
+// readMove blocks for the next keypress and translates it into a move
+// delta, or reports that the user wants to quit. Unrecognized keys return a
+// zero delta, which the caller ignores without redrawing.
+func readMove(r *bufio.Reader) (delta Point, quit bool, err error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return Point{}, false, err
+	}
+
+	if b == 'q' || b == 'Q' {
+		return Point{}, true, nil
+	}
+	if b != 0x1b { // not the start of an escape sequence
+		return Point{}, false, nil
+	}
+
+	b2, err := r.ReadByte()
+	if err != nil || b2 != '[' {
+		return Point{}, false, nil
+	}
+	b3, err := r.ReadByte()
+	if err != nil {
+		return Point{}, false, nil
+	}
+
+	switch b3 {
+	case 'A':
+		return Point{Row: -1}, false, nil
+	case 'B':
+		return Point{Row: 1}, false, nil
+	case 'C':
+		return Point{Col: 1}, false, nil
+	case 'D':
+		return Point{Col: -1}, false, nil
+	default:
+		return Point{}, false, nil
+	}
+}
@@ -0,0 +1,484 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+
+	rawmaze "github.com/Epiq122/go-ai-search/maze"
+)
+
+// Reader builds a Maze from some source: a file on disk, an in-memory list
+// of lines, or a freshly generated layout. Maze.Load wraps FileReader for
+// backward compatibility; new callers can use any Reader directly.
+type Reader interface {
+	Read() (*Maze, error)
+}
+
+// FileReader reads a maze from a file on disk, in either the human-readable
+// "text" format or the bit-packed "packed" format.
+type FileReader struct {
+	Path   string
+	Format string
+}
+
+func (r FileReader) Read() (*Maze, error) {
+	f, err := os.Open(r.Path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open file %s: %w", r.Path, err)
+	}
+	defer f.Close()
+
+	switch r.Format {
+	case "", "text":
+		var lines []string
+		reader := bufio.NewReader(f)
+		for {
+			line, err := reader.ReadString('\n')
+			line = strings.TrimRight(line, "\r\n")
+			if err == io.EOF {
+				if line != "" {
+					lines = append(lines, line)
+				}
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("cannot read file %s: %w", r.Path, err)
+			}
+			lines = append(lines, line)
+		}
+		return parseText(lines)
+	case "packed":
+		return parsePacked(f)
+	default:
+		return nil, fmt.Errorf("unknown maze format %q", r.Format)
+	}
+}
+
+// StringsReader builds a maze from an in-memory text-format layout, the same
+// format FileReader parses from disk. It's useful for tests and fixtures
+// that shouldn't need to touch the filesystem.
+type StringsReader struct {
+	Lines []string
+}
+
+func (r StringsReader) Read() (*Maze, error) {
+	return parseText(r.Lines)
+}
+
+// parseText parses the human-readable maze format shared by FileReader and
+// StringsReader.
+func parseText(lines []string) (*Maze, error) {
+	g := &Maze{}
+
+	foundStart, foundEnd := false, false
+	for _, line := range lines {
+		if strings.Contains(line, "A") {
+			foundStart = true
+		}
+		if strings.Contains(line, "B") {
+			foundEnd = true
+		}
+	}
+
+	g.Height = len(lines)
+	for _, line := range lines {
+		if len(line) > g.Width {
+			g.Width = len(line)
+		}
+	}
+
+	// Donut-maze style labels (e.g. "AA", "BC") are two uppercase letters
+	// sitting next to a walkable cell; pad every row to the same width so
+	// the label scan can look at any (row, col) without bounds-checking
+	// ragged input.
+	grid := make([][]rune, g.Height)
+	for i, line := range lines {
+		row := []rune(line)
+		for len(row) < g.Width {
+			row = append(row, ' ')
+		}
+		grid[i] = row
+	}
+
+	labelEndpoints, labelCells := findLabels(grid)
+	if endpoints, ok := labelEndpoints["AA"]; ok && len(endpoints) == 1 {
+		g.Start = endpoints[0]
+		foundStart = true
+	}
+	if endpoints, ok := labelEndpoints["ZZ"]; ok && len(endpoints) == 1 {
+		g.Goal = endpoints[0]
+		foundEnd = true
+	}
+
+	g.Portals = make(map[Point]Point)
+	for label, endpoints := range labelEndpoints {
+		if label == "AA" || label == "ZZ" || len(endpoints) != 2 {
+			continue
+		}
+		g.Portals[endpoints[0]] = endpoints[1]
+		g.Portals[endpoints[1]] = endpoints[0]
+	}
+
+	if !foundStart {
+		return nil, errors.New("no start point 'A' found in the maze")
+	}
+	if !foundEnd {
+		return nil, errors.New("no end point 'B' found in the maze")
+	}
+
+	g.Raw = rawmaze.NewRawMaze(g.Width, g.Height)
+	for i := range grid {
+		for j, col := range grid[i] {
+			p := Point{Row: i, Col: j}
+			curLetter := fmt.Sprintf("%c", col)
+			switch {
+			case labelCells[p]:
+				// a cell occupied by a two-letter portal label is never
+				// itself walkable, even if the label happens to contain
+				// the letter 'A' or 'B' (e.g. "BC"); Start/Goal only ever
+				// come from AA/ZZ endpoints or a literal single 'A'/'B'.
+				g.Raw.SetWall(j, i, true)
+			case curLetter == "A":
+				g.Start = p
+				g.Raw.SetWall(j, i, false)
+			case curLetter == "B":
+				g.Goal = p
+				g.Raw.SetWall(j, i, false)
+			case curLetter == " ":
+				g.Raw.SetWall(j, i, false)
+			case col >= '0' && col <= '9':
+				g.POIs = append(g.POIs, p)
+				g.Raw.SetWall(j, i, false)
+			case curLetter == "#":
+				g.Raw.SetWall(j, i, true)
+			default:
+				g.Raw.SetWall(j, i, true)
+			}
+		}
+	}
+	return g, nil
+}
+
+// packedMagic identifies the binary "packed" maze format: a bit-packed
+// RawMaze plus the start/goal coordinates, for mazes too large to spell
+// out as human-readable text.
+const packedMagic = "GAIS-MAZE-PACKED\x01"
+
+// parsePacked reads the binary format written alongside a RawMaze: a header
+// of width, height, start and goal coordinates (as big-endian uint32),
+// the maze's packed bit rows, then a trailing portals/POIs section (a
+// uint32 count of portal pairs followed by that many [4]uint32 row/col
+// pairs, then a uint32 count of POIs followed by that many [2]uint32
+// row/col pairs). The trailing section is optional, so packed files
+// written before it existed still load, with no portals or POIs.
+func parsePacked(r io.Reader) (*Maze, error) {
+	magic := make([]byte, len(packedMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != packedMagic {
+		return nil, errors.New("not a packed maze file")
+	}
+
+	var header [6]uint32
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("cannot read packed maze header: %w", err)
+	}
+	width, height := int(header[0]), int(header[1])
+
+	g := &Maze{Width: width, Height: height}
+	g.Start = Point{Row: int(header[2]), Col: int(header[3])}
+	g.Goal = Point{Row: int(header[4]), Col: int(header[5])}
+	g.Portals = make(map[Point]Point)
+
+	g.Raw = rawmaze.NewRawMaze(width, height)
+	rowBytes := g.Raw.RowBytes()
+	for y := 0; y < height; y++ {
+		row := make([]byte, rowBytes)
+		if _, err := io.ReadFull(r, row); err != nil {
+			return nil, fmt.Errorf("cannot read packed maze row %d: %w", y, err)
+		}
+		g.Raw.SetRow(y, row)
+	}
+
+	var portalCount uint32
+	if err := binary.Read(r, binary.BigEndian, &portalCount); err != nil {
+		if err == io.EOF {
+			return g, nil
+		}
+		return nil, fmt.Errorf("cannot read packed maze portal count: %w", err)
+	}
+	for i := uint32(0); i < portalCount; i++ {
+		var pair [4]uint32
+		if err := binary.Read(r, binary.BigEndian, &pair); err != nil {
+			return nil, fmt.Errorf("cannot read packed maze portal %d: %w", i, err)
+		}
+		a := Point{Row: int(pair[0]), Col: int(pair[1])}
+		b := Point{Row: int(pair[2]), Col: int(pair[3])}
+		g.Portals[a] = b
+		g.Portals[b] = a
+	}
+
+	var poiCount uint32
+	if err := binary.Read(r, binary.BigEndian, &poiCount); err != nil {
+		return nil, fmt.Errorf("cannot read packed maze POI count: %w", err)
+	}
+	for i := uint32(0); i < poiCount; i++ {
+		var coords [2]uint32
+		if err := binary.Read(r, binary.BigEndian, &coords); err != nil {
+			return nil, fmt.Errorf("cannot read packed maze POI %d: %w", i, err)
+		}
+		g.POIs = append(g.POIs, Point{Row: int(coords[0]), Col: int(coords[1])})
+	}
+	return g, nil
+}
+
+// WritePacked writes g to w in the binary "packed" format parsePacked
+// reads back: the packedMagic string, a header of width, height, start and
+// goal coordinates (as big-endian uint32), the maze's packed bit rows, and
+// a trailing portals/POIs section so a packed round trip doesn't lose
+// portal warps or multigoal points of interest.
+func (g *Maze) WritePacked(w io.Writer) error {
+	if _, err := io.WriteString(w, packedMagic); err != nil {
+		return fmt.Errorf("cannot write packed maze magic: %w", err)
+	}
+
+	header := [6]uint32{
+		uint32(g.Width), uint32(g.Height),
+		uint32(g.Start.Row), uint32(g.Start.Col),
+		uint32(g.Goal.Row), uint32(g.Goal.Col),
+	}
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return fmt.Errorf("cannot write packed maze header: %w", err)
+	}
+
+	for y, row := range g.Raw.Rows() {
+		if _, err := w.Write(row); err != nil {
+			return fmt.Errorf("cannot write packed maze row %d: %w", y, err)
+		}
+	}
+
+	// Portals is keyed by either endpoint, so each pair appears twice;
+	// write each pair once.
+	seen := make(map[Point]bool)
+	var pairs [][4]uint32
+	for from, to := range g.Portals {
+		if seen[from] || seen[to] {
+			continue
+		}
+		seen[from] = true
+		seen[to] = true
+		pairs = append(pairs, [4]uint32{uint32(from.Row), uint32(from.Col), uint32(to.Row), uint32(to.Col)})
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(pairs))); err != nil {
+		return fmt.Errorf("cannot write packed maze portal count: %w", err)
+	}
+	for i, pair := range pairs {
+		if err := binary.Write(w, binary.BigEndian, pair); err != nil {
+			return fmt.Errorf("cannot write packed maze portal %d: %w", i, err)
+		}
+	}
+
+	if err := binary.Write(w, binary.BigEndian, uint32(len(g.POIs))); err != nil {
+		return fmt.Errorf("cannot write packed maze POI count: %w", err)
+	}
+	for i, poi := range g.POIs {
+		coords := [2]uint32{uint32(poi.Row), uint32(poi.Col)}
+		if err := binary.Write(w, binary.BigEndian, coords); err != nil {
+			return fmt.Errorf("cannot write packed maze POI %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Save writes g to a file at path in the given format. "packed" is the only
+// supported format; any other format, "text" included, returns an error.
+func (g *Maze) Save(path, format string) error {
+	switch format {
+	case "packed":
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("cannot create file %s: %w", path, err)
+		}
+		defer f.Close()
+		return g.WritePacked(f)
+	default:
+		return fmt.Errorf("unsupported save format %q, only \"packed\" is supported", format)
+	}
+}
+
+// findLabels scans a padded maze grid for donut-maze style portal labels:
+// two adjacent uppercase letters (horizontally or vertically) sitting next
+// to a single walkable ' ' cell. It returns, per label text, the walkable
+// endpoint(s) found for that label, plus the set of cells the labels
+// themselves occupy (so parseText can mark them non-walkable).
+func findLabels(grid [][]rune) (map[string][]Point, map[Point]bool) {
+	endpoints := make(map[string][]Point)
+	labelCells := make(map[Point]bool)
+	height := len(grid)
+
+	isUpper := func(r rune) bool { return r >= 'A' && r <= 'Z' }
+	isOpen := func(r, c int) bool {
+		return r >= 0 && r < height && c >= 0 && c < len(grid[r]) && grid[r][c] == ' '
+	}
+
+	for r := 0; r < height; r++ {
+		for c := 0; c < len(grid[r]); c++ {
+			if !isUpper(grid[r][c]) || labelCells[Point{Row: r, Col: c}] {
+				continue
+			}
+			// horizontal pair
+			if c+1 < len(grid[r]) && isUpper(grid[r][c+1]) {
+				label := string(grid[r][c]) + string(grid[r][c+1])
+				var endpoint Point
+				switch {
+				case isOpen(r, c-1):
+					endpoint = Point{Row: r, Col: c - 1}
+				case isOpen(r, c+2):
+					endpoint = Point{Row: r, Col: c + 2}
+				default:
+					continue
+				}
+				endpoints[label] = append(endpoints[label], endpoint)
+				labelCells[Point{Row: r, Col: c}] = true
+				labelCells[Point{Row: r, Col: c + 1}] = true
+				continue
+			}
+			// vertical pair
+			if r+1 < height && isUpper(grid[r+1][c]) {
+				label := string(grid[r][c]) + string(grid[r+1][c])
+				var endpoint Point
+				switch {
+				case isOpen(r-1, c):
+					endpoint = Point{Row: r - 1, Col: c}
+				case isOpen(r+2, c):
+					endpoint = Point{Row: r + 2, Col: c}
+				default:
+					continue
+				}
+				endpoints[label] = append(endpoints[label], endpoint)
+				labelCells[Point{Row: r, Col: c}] = true
+				labelCells[Point{Row: r + 1, Col: c}] = true
+			}
+		}
+	}
+	return endpoints, labelCells
+}
+
+// GeneratorReader builds a brand-new maze with a recursive-backtracker
+// carve: every cell starts walled off, then a depth-first walk over the
+// logical (odd-spaced) cell grid knocks down walls between the current
+// cell and a randomly chosen unvisited neighbor, backtracking through an
+// explicit stack when a cell has none left. Width and Height are rounded
+// up to odd numbers (and a minimum of 5) so every cell has a surrounding
+// wall. Start and Goal are placed at two path cells found by running a
+// BFS from an arbitrary cell to find a farthest cell, then repeating from
+// there: the second farthest cell is a good proxy for the two most
+// separated points in the maze.
+type GeneratorReader struct {
+	Width  int
+	Height int
+	Seed   int64
+}
+
+func (r GeneratorReader) Read() (*Maze, error) {
+	width, height := r.Width, r.Height
+	if width < 5 {
+		width = 5
+	}
+	if height < 5 {
+		height = 5
+	}
+	if width%2 == 0 {
+		width++
+	}
+	if height%2 == 0 {
+		height++
+	}
+
+	cols, rows := (width-1)/2, (height-1)/2
+	raw := rawmaze.NewRawMaze(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			raw.SetWall(x, y, true)
+		}
+	}
+
+	type cell struct{ cx, cy int }
+	dirs := []cell{{0, -1}, {0, 1}, {-1, 0}, {1, 0}}
+
+	rng := rand.New(rand.NewSource(r.Seed))
+	visited := make([][]bool, rows)
+	for i := range visited {
+		visited[i] = make([]bool, cols)
+	}
+
+	start := cell{rng.Intn(cols), rng.Intn(rows)}
+	visited[start.cy][start.cx] = true
+	raw.SetWall(2*start.cx+1, 2*start.cy+1, false)
+
+	stack := []cell{start}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+
+		var unvisited []cell
+		for _, d := range dirs {
+			nx, ny := cur.cx+d.cx, cur.cy+d.cy
+			if nx >= 0 && nx < cols && ny >= 0 && ny < rows && !visited[ny][nx] {
+				unvisited = append(unvisited, cell{nx, ny})
+			}
+		}
+
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[rng.Intn(len(unvisited))]
+		wallX := 2*cur.cx + 1 + (next.cx - cur.cx)
+		wallY := 2*cur.cy + 1 + (next.cy - cur.cy)
+		raw.SetWall(wallX, wallY, false)
+		raw.SetWall(2*next.cx+1, 2*next.cy+1, false)
+		visited[next.cy][next.cx] = true
+		stack = append(stack, next)
+	}
+
+	g := &Maze{Width: width, Height: height, Raw: raw, Portals: make(map[Point]Point)}
+
+	origin := Point{Row: 2*start.cy + 1, Col: 2*start.cx + 1}
+	far1 := farthestPoint(g, origin)
+	far2 := farthestPoint(g, far1)
+	g.Start, g.Goal = far1, far2
+	return g, nil
+}
+
+// farthestPoint runs a BFS over the walkable cells of g starting at from
+// and returns the last cell reached, i.e. one of the cells farthest away
+// by path distance.
+func farthestPoint(g *Maze, from Point) Point {
+	visited := map[Point]bool{from: true}
+	queue := []Point{from}
+	farthest := from
+
+	dirs := []Point{{Row: -1}, {Row: 1}, {Col: -1}, {Col: 1}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		farthest = cur
+
+		for _, d := range dirs {
+			next := Point{Row: cur.Row + d.Row, Col: cur.Col + d.Col}
+			if next.Row < 0 || next.Row >= g.Height || next.Col < 0 || next.Col >= g.Width {
+				continue
+			}
+			if visited[next] || g.Raw.IsWall(next.Col, next.Row) {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return farthest
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestStringsReaderMatchesFileReader checks that StringsReader parses an
+// in-memory maze the same way FileReader parses the equivalent file.
+func TestStringsReaderMatchesFileReader(t *testing.T) {
+	lines := []string{
+		"###########",
+		"#A 1   2 B#",
+		"###########",
+	}
+
+	m, err := (StringsReader{Lines: lines}).Read()
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	if m.Start != (Point{Row: 1, Col: 1}) {
+		t.Errorf("expected start (1,1), got %v", m.Start)
+	}
+	if m.Goal != (Point{Row: 1, Col: 9}) {
+		t.Errorf("expected goal (1,9), got %v", m.Goal)
+	}
+	if len(m.POIs) != 2 {
+		t.Fatalf("expected 2 points of interest, got %d", len(m.POIs))
+	}
+}
+
+// TestGeneratorReaderProducesSolvableMaze checks that a generated maze has
+// a Start and Goal connected by some walkable path.
+func TestGeneratorReaderProducesSolvableMaze(t *testing.T) {
+	m, err := (GeneratorReader{Width: 15, Height: 15, Seed: 42}).Read()
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	if m.Start == m.Goal {
+		t.Fatal("expected Start and Goal to be distinct cells")
+	}
+
+	var s BreadthFirstSearch
+	s.Game = m
+	s.Solve()
+
+	if len(m.Solution.Cells) == 0 {
+		t.Fatal("expected the generated maze to be solvable, but BFS found no solution")
+	}
+}
+
+// TestPortalLabelContainingAOrBDoesNotClobberStartGoal checks that a portal
+// label whose letters happen to include 'A' or 'B' (e.g. "BC", entirely
+// normal in the AoC day-20 alphabet) doesn't get misread as the literal
+// start/goal marker: the label cells stay walls, and Start/Goal stay at the
+// maze's actual 'A'/'B' cells.
+func TestPortalLabelContainingAOrBDoesNotClobberStartGoal(t *testing.T) {
+	rows := []string{
+		strings.Repeat("#", 11),
+		"#A" + strings.Repeat("#", 7) + "B#",
+		"#" + " " + strings.Repeat("#", 7) + " " + "#",
+		"#" + " " + "BC" + "###" + "BC" + " " + "#",
+		strings.Repeat("#", 11),
+	}
+
+	m, err := (StringsReader{Lines: rows}).Read()
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	if m.Start != (Point{Row: 1, Col: 1}) {
+		t.Errorf("expected start to stay at the literal 'A', got %v", m.Start)
+	}
+	if m.Goal != (Point{Row: 1, Col: 9}) {
+		t.Errorf("expected goal to stay at the literal 'B', got %v", m.Goal)
+	}
+	if len(m.Portals) != 2 {
+		t.Fatalf("expected a single bidirectional portal pair (2 map entries), got %d", len(m.Portals))
+	}
+
+	labelCells := []Point{{Row: 3, Col: 2}, {Row: 3, Col: 3}, {Row: 3, Col: 7}, {Row: 3, Col: 8}}
+	for _, p := range labelCells {
+		if !m.Raw.IsWall(p.Col, p.Row) {
+			t.Errorf("expected label cell %v to be a wall, not walkable", p)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+// Solver is satisfied by the frontier-based search strategies
+// (DepthFirstSearch, BreadthFirstSearch, GreedyBestFirstSearch, AStarSearch,
+// DijkstraSearch). MultiGoalSolver has only Solve and does not satisfy it,
+// and main dispatches on the -search flag through its solveX helpers.
+type Solver interface {
+	Add(i *Node)
+	Remove() (*Node, error)
+	ContainsState(i *Node) bool
+	Empty() bool
+	Solve()
+}
+
+// heuristic returns the Manhattan distance between a and b, used by
+// GreedyBestFirstSearch and AStarSearch to estimate distance to the goal.
+func heuristic(a, b Point) int {
+	return abs(a.Row-b.Row) + abs(a.Col-b.Col)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// pqItem is one entry in a priorityQueue: a frontier node ranked by
+// priority (lower explored first).
+type pqItem struct {
+	node     *Node
+	priority int
+	index    int
+}
+
+// priorityQueue implements container/heap.Interface and backs the frontiers
+// of GreedyBestFirstSearch, AStarSearch and DijkstraSearch. Dijkstra only
+// needs it for unit-weight cells today, but keeping it heap-based means
+// weighted cells can be supported later without changing the frontier type.
+type priorityQueue []*pqItem
+
+func (pq priorityQueue) Len() int { return len(pq) }
+
+func (pq priorityQueue) Less(i, j int) bool {
+	return pq[i].priority < pq[j].priority
+}
+
+func (pq priorityQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index = i
+	pq[j].index = j
+}
+
+func (pq *priorityQueue) Push(x any) {
+	item := x.(*pqItem)
+	item.index = len(*pq)
+	*pq = append(*pq, item)
+}
+
+func (pq *priorityQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*pq = old[:n-1]
+	return item
+}
+
+// indexOfState returns the frontier index of the item holding state, or -1.
+func (pq priorityQueue) indexOfState(state Point) int {
+	for i, item := range pq {
+		if item.node.State == state {
+			return i
+		}
+	}
+	return -1
+}